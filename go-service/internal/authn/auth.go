@@ -0,0 +1,111 @@
+// Package authn provides gorilla/mux middleware for authenticating and
+// authorizing incoming requests: a JWT bearer-token check with role claims
+// (RequireAuth) and a double-submit CSRF check for mutating requests
+// (RequireCSRF).
+package authn
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+)
+
+// Claims is the set of JWT claims the backend is expected to issue. Subject
+// carries the student ID for "student" role tokens.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+}
+
+const (
+	RoleAdmin   = "admin"
+	RoleTeacher = "teacher"
+	RoleStudent = "student"
+)
+
+type contextKey int
+
+const claimsContextKey contextKey = 0
+
+// ClaimsFromContext returns the claims attached to the request by
+// RequireAuth, if any.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(Claims)
+	return claims, ok
+}
+
+// RequireAuth returns mux middleware that validates an incoming bearer JWT
+// using keyfunc and enforces role-based access: "admin" and "teacher" may
+// access any route, a "student" token is rejected with 403 on a route whose
+// {id} path variable differs from its subject claim, and any other role is
+// rejected with 403. Routes with no {id} path variable are not checked
+// against the subject, so student tokens pass them (see RequireStaff for
+// those routes). If required is false, requests
+// with no Authorization header are let through unauthenticated so local
+// development can work without a backend JWKS endpoint.
+func RequireAuth(keyfunc jwt.Keyfunc, required bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				if !required {
+					next.ServeHTTP(w, r)
+					return
+				}
+				http.Error(w, "Missing authorization token", http.StatusUnauthorized)
+				return
+			}
+
+			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+
+			var claims Claims
+			token, err := jwt.ParseWithClaims(tokenString, &claims, keyfunc)
+			if err != nil || !token.Valid {
+				http.Error(w, "Invalid authorization token", http.StatusUnauthorized)
+				return
+			}
+
+			switch claims.Role {
+			case RoleAdmin, RoleTeacher:
+				// May access any student's report.
+			case RoleStudent:
+				if id, ok := mux.Vars(r)["id"]; ok && id != claims.Subject {
+					http.Error(w, "Forbidden", http.StatusForbidden)
+					return
+				}
+			default:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), claimsContextKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireStaff returns mux middleware that restricts access to requests
+// carrying "admin" or "teacher" claims, rejecting "student" tokens (and any
+// other role) with 403. Mount it on routes that have no {id} path variable to
+// check against a student's own subject claim — bulk/aggregate routes such as
+// the reports ZIP, status manifest, and template endpoints, where RequireAuth's
+// per-student check never applies and would otherwise let any authenticated
+// student through. If required is false (local dev, no JWKS configured), the
+// check is skipped since RequireAuth never attaches claims in that mode.
+func RequireStaff(required bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if required {
+				claims, ok := ClaimsFromContext(r.Context())
+				if !ok || (claims.Role != RoleAdmin && claims.Role != RoleTeacher) {
+					http.Error(w, "Forbidden", http.StatusForbidden)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package authn
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+)
+
+var testSecret = []byte("test-secret")
+
+func testKeyfunc(token *jwt.Token) (interface{}, error) {
+	return testSecret, nil
+}
+
+func signTestToken(t *testing.T, role, subject string) string {
+	t.Helper()
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: subject},
+		Role:             role,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(testSecret)
+	if err != nil {
+		t.Fatalf("failed to sign test token: %v", err)
+	}
+	return signed
+}
+
+func newTestRouter() *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/api/v1/students/{id}/report", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return r
+}
+
+func TestRequireAuthMissingToken(t *testing.T) {
+	r := newTestRouter()
+	r.Use(RequireAuth(testKeyfunc, true))
+
+	req := httptest.NewRequest("GET", "/api/v1/students/1/report", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("got status %d, want %d", rr.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRequireAuthNotRequiredAllowsMissingToken(t *testing.T) {
+	r := newTestRouter()
+	r.Use(RequireAuth(testKeyfunc, false))
+
+	req := httptest.NewRequest("GET", "/api/v1/students/1/report", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rr.Code, http.StatusOK)
+	}
+}
+
+func TestRequireAuthStudentRoleForbiddenForOtherStudent(t *testing.T) {
+	r := newTestRouter()
+	r.Use(RequireAuth(testKeyfunc, true))
+
+	req := httptest.NewRequest("GET", "/api/v1/students/1/report", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, RoleStudent, "2"))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want %d", rr.Code, http.StatusForbidden)
+	}
+}
+
+func TestRequireAuthStudentRoleAllowedForSelf(t *testing.T) {
+	r := newTestRouter()
+	r.Use(RequireAuth(testKeyfunc, true))
+
+	req := httptest.NewRequest("GET", "/api/v1/students/1/report", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, RoleStudent, "1"))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rr.Code, http.StatusOK)
+	}
+}
+
+func TestRequireAuthAdminRoleAllowedForAnyStudent(t *testing.T) {
+	r := newTestRouter()
+	r.Use(RequireAuth(testKeyfunc, true))
+
+	req := httptest.NewRequest("GET", "/api/v1/students/1/report", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, RoleAdmin, "99"))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rr.Code, http.StatusOK)
+	}
+}
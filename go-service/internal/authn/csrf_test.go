@@ -0,0 +1,65 @@
+package authn
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestRequireCSRFAllowsGet(t *testing.T) {
+	handler := RequireCSRF("secret")(okHandler())
+
+	req := httptest.NewRequest("GET", "/api/v1/template", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rr.Code, http.StatusOK)
+	}
+}
+
+func TestRequireCSRFRejectsMissingSession(t *testing.T) {
+	handler := RequireCSRF("secret")(okHandler())
+
+	req := httptest.NewRequest("PUT", "/api/v1/template", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want %d", rr.Code, http.StatusForbidden)
+	}
+}
+
+func TestRequireCSRFRejectsMismatchedToken(t *testing.T) {
+	handler := RequireCSRF("secret")(okHandler())
+
+	req := httptest.NewRequest("PUT", "/api/v1/template", nil)
+	req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "session-123"})
+	req.Header.Set("X-CSRF-Token", "not-the-right-token")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("got status %d, want %d", rr.Code, http.StatusForbidden)
+	}
+}
+
+func TestRequireCSRFAllowsMatchingToken(t *testing.T) {
+	handler := RequireCSRF("secret")(okHandler())
+
+	req := httptest.NewRequest("PUT", "/api/v1/template", nil)
+	req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "session-123"})
+	req.Header.Set("X-CSRF-Token", csrfToken("session-123", "secret"))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rr.Code, http.StatusOK)
+	}
+}
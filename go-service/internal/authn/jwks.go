@@ -0,0 +1,21 @@
+package authn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// NewJWKSKeyfunc fetches the JWKS at jwksURL once and returns a jwt.Keyfunc
+// backed by it. The returned keyfunc refreshes the key set periodically in
+// the background, so token validation keeps working across key rotation
+// without a restart.
+func NewJWKSKeyfunc(jwksURL string) (jwt.Keyfunc, error) {
+	kf, err := keyfunc.NewDefaultCtx(context.Background(), []string{jwksURL})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching JWKS from %s: %w", jwksURL, err)
+	}
+	return kf.Keyfunc, nil
+}
@@ -0,0 +1,93 @@
+// Package backend talks to the school management backend that supplies
+// student data. It abstracts over how the service authenticates with that
+// backend (session cookies today, OAuth2/OIDC for any standards-compliant
+// backend) behind a single Client interface.
+package backend
+
+import "fmt"
+
+// StudentDetail represents the student data structure from the backend API.
+type StudentDetail struct {
+	ID                 int    `json:"id"`
+	Name               string `json:"name"`
+	Email              string `json:"email"`
+	SystemAccess       bool   `json:"systemAccess"`
+	Phone              string `json:"phone"`
+	Gender             string `json:"gender"`
+	DOB                string `json:"dob"`
+	Class              string `json:"class"`
+	Section            string `json:"section"`
+	Roll               string `json:"roll"`
+	FatherName         string `json:"fatherName"`
+	FatherPhone        string `json:"fatherPhone"`
+	MotherName         string `json:"motherName"`
+	MotherPhone        string `json:"motherPhone"`
+	GuardianName       string `json:"guardianName"`
+	GuardianPhone      string `json:"guardianPhone"`
+	RelationOfGuardian string `json:"relationOfGuardian"`
+	CurrentAddress     string `json:"currentAddress"`
+	PermanentAddress   string `json:"permanentAddress"`
+	AdmissionDate      string `json:"admissionDate"`
+	ReporterName       string `json:"reporterName"`
+}
+
+// AuthMode selects which Client implementation NewClient constructs.
+type AuthMode string
+
+const (
+	// AuthModeCookie authenticates with a username/password login call and
+	// replays the resulting session cookies, refreshing them on a timer.
+	AuthModeCookie AuthMode = "cookie"
+	// AuthModeOAuth2 authenticates via the OAuth2 client-credentials grant.
+	AuthModeOAuth2 AuthMode = "oauth2"
+	// AuthModeOIDC authenticates via an OIDC provider's discovery document
+	// plus the OAuth2 client-credentials grant.
+	AuthModeOIDC AuthMode = "oidc"
+)
+
+// Config holds everything a Client implementation needs to talk to the
+// backend and authenticate with it.
+type Config struct {
+	BackendURL string
+	AuthMode   AuthMode
+
+	// Used by AuthModeCookie.
+	AdminEmail    string
+	AdminPassword string
+
+	// Used by AuthModeOAuth2 and AuthModeOIDC.
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// Client fetches student data from the backend, handling authentication and
+// token refresh internally.
+type Client interface {
+	// FetchStudent returns a single student by ID.
+	FetchStudent(studentID string) (*StudentDetail, error)
+	// FetchStudentETag behaves like FetchStudent but sends ifNoneMatch as an
+	// If-None-Match header, so a caller holding a cached copy can cheaply
+	// revalidate it. notModified is true, and student is nil, when the
+	// backend responds 304 Not Modified.
+	FetchStudentETag(studentID, ifNoneMatch string) (student *StudentDetail, etag string, notModified bool, err error)
+	// FetchStudents enumerates students, optionally filtered by class and
+	// section. An empty filter returns every student.
+	FetchStudents(class, section string) ([]StudentDetail, error)
+}
+
+// NewClient builds the Client implementation selected by cfg.AuthMode. Cookie
+// mode logs in and OIDC mode fetches the provider's discovery document before
+// returning, so misconfiguration there fails fast; OAuth2 mode contacts
+// nothing here and fetches its first token on the first request.
+func NewClient(cfg Config) (Client, error) {
+	switch cfg.AuthMode {
+	case "", AuthModeCookie:
+		return newCookieClient(cfg)
+	case AuthModeOAuth2, AuthModeOIDC:
+		return newOAuth2Client(cfg)
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q", cfg.AuthMode)
+	}
+}
@@ -0,0 +1,64 @@
+package backend
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewClientCookieMode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth/login" {
+			t.Errorf("unexpected login path %q", r.URL.Path)
+		}
+		http.SetCookie(w, &http.Cookie{Name: "accessToken", Value: "token"})
+		json.NewEncoder(w).Encode(loginResponse{})
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(Config{AuthMode: AuthModeCookie, BackendURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, ok := client.(*cookieClient); !ok {
+		t.Errorf("NewClient(AuthModeCookie) = %T, want *cookieClient", client)
+	}
+}
+
+func TestNewClientDefaultModeIsCookie(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(loginResponse{})
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(Config{BackendURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, ok := client.(*cookieClient); !ok {
+		t.Errorf("NewClient(\"\") = %T, want *cookieClient", client)
+	}
+}
+
+func TestNewClientOAuth2Mode(t *testing.T) {
+	client, err := NewClient(Config{
+		AuthMode:   AuthModeOAuth2,
+		BackendURL: "https://backend.example.com",
+		IssuerURL:  "https://issuer.example.com",
+		ClientID:   "client-id",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, ok := client.(*oauth2Client); !ok {
+		t.Errorf("NewClient(AuthModeOAuth2) = %T, want *oauth2Client", client)
+	}
+}
+
+func TestNewClientUnknownModeErrors(t *testing.T) {
+	_, err := NewClient(Config{AuthMode: "carrier-pigeon"})
+	if err == nil {
+		t.Fatal("NewClient() error = nil, want error for unknown auth mode")
+	}
+}
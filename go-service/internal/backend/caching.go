@@ -0,0 +1,76 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jon-deo/Tasks/go-service/internal/cache"
+)
+
+// cachingClient wraps a Client and caches FetchStudent results locally, so
+// repeat calls for the same student within ttl skip the backend entirely.
+// Once ttl has passed it revalidates with a conditional request instead of
+// paying for a full response body when the backend reports the data is
+// unchanged. Staleness bookkeeping (the last known ETag and value for a
+// student no longer fresh enough to serve) lives in store itself via
+// GetStale, so it's bounded by whatever eviction store implements (e.g.
+// MemoryCache's LRU, or DiskCache's periodic sweep) instead of growing in a
+// second, unbounded map here.
+type cachingClient struct {
+	Client
+	store cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachingClient wraps client so repeat FetchStudent calls for the same ID
+// within ttl skip the backend, using store to hold the cached values.
+func NewCachingClient(client Client, store cache.Cache, ttl time.Duration) Client {
+	return &cachingClient{Client: client, store: store, ttl: ttl}
+}
+
+func (c *cachingClient) FetchStudent(studentID string) (*StudentDetail, error) {
+	key := studentCacheKey(studentID)
+
+	if raw, _, ok := c.store.Get(key); ok {
+		var student StudentDetail
+		if err := json.Unmarshal(raw, &student); err == nil {
+			return &student, nil
+		}
+	}
+
+	staleRaw, staleETag, hasStale := c.store.GetStale(key)
+
+	student, etag, notModified, err := c.Client.FetchStudentETag(studentID, staleETag)
+	if err != nil {
+		return nil, err
+	}
+
+	if notModified {
+		if !hasStale {
+			// We had nothing to revalidate against; fall back to a full fetch.
+			student, etag, _, err = c.Client.FetchStudentETag(studentID, "")
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			c.store.Set(key, staleRaw, etag, c.ttl)
+			var cached StudentDetail
+			if err := json.Unmarshal(staleRaw, &cached); err != nil {
+				return nil, fmt.Errorf("error decoding cached student: %w", err)
+			}
+			return &cached, nil
+		}
+	}
+
+	raw, err := json.Marshal(student)
+	if err == nil {
+		c.store.Set(key, raw, etag, c.ttl)
+	}
+
+	return student, nil
+}
+
+func studentCacheKey(studentID string) string {
+	return "student:" + studentID
+}
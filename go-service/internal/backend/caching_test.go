@@ -0,0 +1,105 @@
+package backend
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jon-deo/Tasks/go-service/internal/cache"
+)
+
+// fakeETagClient is a Client whose FetchStudentETag is scripted per call, so
+// tests can exercise cachingClient's revalidation paths without a real
+// backend.
+type fakeETagClient struct {
+	calls []func(studentID, ifNoneMatch string) (*StudentDetail, string, bool, error)
+	n     int
+}
+
+func (f *fakeETagClient) FetchStudent(studentID string) (*StudentDetail, error) {
+	student, _, _, err := f.FetchStudentETag(studentID, "")
+	return student, err
+}
+
+func (f *fakeETagClient) FetchStudentETag(studentID, ifNoneMatch string) (*StudentDetail, string, bool, error) {
+	if f.n >= len(f.calls) {
+		return nil, "", false, fmt.Errorf("unexpected call %d", f.n)
+	}
+	call := f.calls[f.n]
+	f.n++
+	return call(studentID, ifNoneMatch)
+}
+
+func (f *fakeETagClient) FetchStudents(class, section string) ([]StudentDetail, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestCachingClientServesFreshValueWithoutBackendCall(t *testing.T) {
+	store, err := cache.NewMemoryCache(8)
+	if err != nil {
+		t.Fatalf("NewMemoryCache() error = %v", err)
+	}
+
+	inner := &fakeETagClient{calls: []func(string, string) (*StudentDetail, string, bool, error){
+		func(studentID, ifNoneMatch string) (*StudentDetail, string, bool, error) {
+			return &StudentDetail{ID: 1, Name: "Ada"}, "etag-1", false, nil
+		},
+	}}
+	c := NewCachingClient(inner, store, time.Minute)
+
+	first, err := c.FetchStudent("1")
+	if err != nil {
+		t.Fatalf("FetchStudent() error = %v", err)
+	}
+	if first.Name != "Ada" {
+		t.Errorf("FetchStudent().Name = %q, want %q", first.Name, "Ada")
+	}
+
+	second, err := c.FetchStudent("1")
+	if err != nil {
+		t.Fatalf("FetchStudent() error = %v", err)
+	}
+	if second.Name != "Ada" {
+		t.Errorf("FetchStudent().Name = %q, want %q", second.Name, "Ada")
+	}
+	if inner.n != 1 {
+		t.Errorf("backend was called %d times, want 1 (second call should be served from cache)", inner.n)
+	}
+}
+
+func TestCachingClientRevalidatesAfterExpiryOn304(t *testing.T) {
+	store, err := cache.NewMemoryCache(8)
+	if err != nil {
+		t.Fatalf("NewMemoryCache() error = %v", err)
+	}
+
+	inner := &fakeETagClient{calls: []func(string, string) (*StudentDetail, string, bool, error){
+		func(studentID, ifNoneMatch string) (*StudentDetail, string, bool, error) {
+			return &StudentDetail{ID: 1, Name: "Ada"}, "etag-1", false, nil
+		},
+		func(studentID, ifNoneMatch string) (*StudentDetail, string, bool, error) {
+			if ifNoneMatch != "etag-1" {
+				t.Errorf("revalidation If-None-Match = %q, want %q", ifNoneMatch, "etag-1")
+			}
+			return nil, ifNoneMatch, true, nil
+		},
+	}}
+	// A negative TTL means the first Set() is immediately expired, forcing
+	// the second FetchStudent to revalidate instead of serving fresh.
+	c := NewCachingClient(inner, store, -time.Minute)
+
+	if _, err := c.FetchStudent("1"); err != nil {
+		t.Fatalf("FetchStudent() error = %v", err)
+	}
+
+	second, err := c.FetchStudent("1")
+	if err != nil {
+		t.Fatalf("FetchStudent() error = %v", err)
+	}
+	if second.Name != "Ada" {
+		t.Errorf("FetchStudent().Name = %q, want %q (served from the revalidated stale entry)", second.Name, "Ada")
+	}
+	if inner.n != 2 {
+		t.Errorf("backend was called %d times, want 2", inner.n)
+	}
+}
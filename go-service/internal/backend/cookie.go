@@ -0,0 +1,157 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// loginResponse represents the response from the backend's login endpoint.
+type loginResponse struct {
+	AccessToken string `json:"accessToken"`
+	User        struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+		Role string `json:"role"`
+	} `json:"user"`
+}
+
+// cookieClient authenticates with the backend's username/password login
+// endpoint and replays the resulting session cookies on every request,
+// re-authenticating on a fixed schedule. This is the historical behavior of
+// the service, preserved for backends that don't speak OAuth2/OIDC.
+type cookieClient struct {
+	cfg        Config
+	httpClient *http.Client
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	csrfToken    string
+	lastAuth     time.Time
+}
+
+func newCookieClient(cfg Config) (*cookieClient, error) {
+	c := &cookieClient{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	if err := c.authenticate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *cookieClient) authenticate() error {
+	loginURL := fmt.Sprintf("%s/auth/login", c.cfg.BackendURL)
+	loginData := map[string]string{
+		"username": c.cfg.AdminEmail,
+		"password": c.cfg.AdminPassword,
+	}
+	loginJSON, err := json.Marshal(loginData)
+	if err != nil {
+		return fmt.Errorf("error preparing login request: %w", err)
+	}
+
+	resp, err := c.httpClient.Post(loginURL, "application/json", bytes.NewBuffer(loginJSON))
+	if err != nil {
+		return fmt.Errorf("error authenticating with backend: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("backend authentication failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	c.mu.Lock()
+	for _, cookie := range resp.Cookies() {
+		switch cookie.Name {
+		case "accessToken":
+			c.accessToken = cookie.Value
+		case "refreshToken":
+			c.refreshToken = cookie.Value
+		case "csrfToken":
+			c.csrfToken = cookie.Value
+		}
+	}
+	c.lastAuth = time.Now()
+	c.mu.Unlock()
+
+	var login loginResponse
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		return fmt.Errorf("error parsing login response: %w", err)
+	}
+
+	return nil
+}
+
+// ensureFreshTokens re-authenticates if the session cookies are old enough
+// that the backend is likely to have expired them.
+func (c *cookieClient) ensureFreshTokens() error {
+	c.mu.Lock()
+	stale := time.Since(c.lastAuth) > 14*time.Minute
+	c.mu.Unlock()
+	if !stale {
+		return nil
+	}
+	return c.authenticate()
+}
+
+func (c *cookieClient) addAuthCookies(req *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	req.AddCookie(&http.Cookie{Name: "accessToken", Value: c.accessToken})
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: c.refreshToken})
+	req.AddCookie(&http.Cookie{Name: "csrfToken", Value: c.csrfToken})
+	req.Header.Set("X-CSRF-Token", c.csrfToken)
+}
+
+func (c *cookieClient) do(req *http.Request) (*http.Response, error) {
+	if err := c.ensureFreshTokens(); err != nil {
+		return nil, fmt.Errorf("error refreshing auth tokens: %w", err)
+	}
+	c.addAuthCookies(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		if err := c.authenticate(); err != nil {
+			return nil, fmt.Errorf("error re-authenticating after 401: %w", err)
+		}
+		retryReq, err := http.NewRequest(req.Method, req.URL.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error rebuilding request: %w", err)
+		}
+		retryReq.Header = req.Header.Clone()
+		req = retryReq
+		c.addAuthCookies(req)
+		resp, err = c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (c *cookieClient) FetchStudent(studentID string) (*StudentDetail, error) {
+	student, _, _, err := c.FetchStudentETag(studentID, "")
+	return student, err
+}
+
+func (c *cookieClient) FetchStudentETag(studentID, ifNoneMatch string) (*StudentDetail, string, bool, error) {
+	return fetchStudentETag(c.do, c.cfg.BackendURL, studentID, ifNoneMatch)
+}
+
+func (c *cookieClient) FetchStudents(class, section string) ([]StudentDetail, error) {
+	return fetchStudents(c.do, c.cfg.BackendURL, class, section)
+}
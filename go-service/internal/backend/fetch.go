@@ -0,0 +1,92 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// doer issues an HTTP request the way a Client implementation authenticates
+// it (replaying session cookies, attaching an OAuth2 bearer token, ...),
+// returning the raw response so the caller can apply the shared status/body
+// handling below.
+type doer func(req *http.Request) (*http.Response, error)
+
+// fetchStudentETag is the shared GET-one-student-and-decode logic behind
+// FetchStudentETag, parameterized by how the request is actually issued so
+// each auth strategy only has to implement doer.
+func fetchStudentETag(do doer, backendURL, studentID, ifNoneMatch string) (*StudentDetail, string, bool, error) {
+	reqURL := fmt.Sprintf("%s/students/%s", backendURL, studentID)
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error creating request: %w", err)
+	}
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+
+	resp, err := do(req)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error making request to backend: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, ifNoneMatch, true, nil
+	case http.StatusNotFound:
+		return nil, "", false, fmt.Errorf("student with ID %s not found", studentID)
+	case http.StatusOK:
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", false, fmt.Errorf("backend API returned non-200 status: %d, body: %s", resp.StatusCode, body)
+	}
+
+	var student StudentDetail
+	if err := json.NewDecoder(resp.Body).Decode(&student); err != nil {
+		return nil, "", false, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return &student, resp.Header.Get("ETag"), false, nil
+}
+
+// fetchStudents is the shared list-and-decode logic behind FetchStudents,
+// parameterized the same way as fetchStudentETag.
+func fetchStudents(do doer, backendURL, class, section string) ([]StudentDetail, error) {
+	reqURL := fmt.Sprintf("%s/students", backendURL)
+	params := url.Values{}
+	if class != "" {
+		params.Set("class", class)
+	}
+	if section != "" {
+		params.Set("section", section)
+	}
+	if encoded := params.Encode(); encoded != "" {
+		reqURL = fmt.Sprintf("%s?%s", reqURL, encoded)
+	}
+
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to backend: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("backend API returned non-200 status: %d, body: %s", resp.StatusCode, body)
+	}
+
+	var students []StudentDetail
+	if err := json.NewDecoder(resp.Body).Decode(&students); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return students, nil
+}
@@ -0,0 +1,59 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// oauth2Client authenticates via the OAuth2 client-credentials grant (or,
+// for AuthModeOIDC, discovers the token endpoint from the issuer's OIDC
+// metadata first). Token refresh is handled entirely by the underlying
+// oauth2.TokenSource, so there's no manual expiry bookkeeping or 401 retry
+// loop here.
+type oauth2Client struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+func newOAuth2Client(cfg Config) (*oauth2Client, error) {
+	ctx := context.Background()
+
+	tokenURL := fmt.Sprintf("%s/oauth2/token", cfg.IssuerURL)
+	if cfg.AuthMode == AuthModeOIDC {
+		provider, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+		if err != nil {
+			return nil, fmt.Errorf("error discovering OIDC provider %s: %w", cfg.IssuerURL, err)
+		}
+		tokenURL = provider.Endpoint().TokenURL
+	}
+
+	ccConfig := &clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       cfg.Scopes,
+	}
+
+	return &oauth2Client{
+		cfg:        cfg,
+		httpClient: ccConfig.Client(ctx),
+	}, nil
+}
+
+func (c *oauth2Client) FetchStudent(studentID string) (*StudentDetail, error) {
+	student, _, _, err := c.FetchStudentETag(studentID, "")
+	return student, err
+}
+
+func (c *oauth2Client) FetchStudentETag(studentID, ifNoneMatch string) (*StudentDetail, string, bool, error) {
+	return fetchStudentETag(c.httpClient.Do, c.cfg.BackendURL, studentID, ifNoneMatch)
+}
+
+func (c *oauth2Client) FetchStudents(class, section string) ([]StudentDetail, error) {
+	return fetchStudents(c.httpClient.Do, c.cfg.BackendURL, class, section)
+}
+
@@ -0,0 +1,57 @@
+// Package cache provides a small byte-blob cache with per-entry TTL and an
+// ETag alongside each value, used to avoid redundant backend fetches and PDF
+// renders.
+package cache
+
+import (
+	"fmt"
+	"time"
+)
+
+// Cache stores a value and its ETag under a key, expiring entries after
+// their TTL.
+type Cache interface {
+	// Get returns the cached value and ETag for key, and whether the entry
+	// exists and hasn't expired.
+	Get(key string) (value []byte, etag string, ok bool)
+	// GetStale returns the last known value and ETag for key even if its TTL
+	// has expired, so a caller can revalidate with a conditional request
+	// instead of paying for an unconditional fetch. ok is false only if key
+	// has never been stored, or was since evicted by the cache's own
+	// bounding (LRU size, disk eviction, etc.) — so callers get staleness
+	// tracking for free instead of keeping their own unbounded bookkeeping.
+	GetStale(key string) (value []byte, etag string, ok bool)
+	// Set stores value under key with the given ETag, expiring it after ttl.
+	Set(key string, value []byte, etag string, ttl time.Duration)
+}
+
+// Config selects which Cache implementation New builds.
+type Config struct {
+	// Dir, if set, backs the cache with gob-encoded files under this
+	// directory instead of an in-memory LRU.
+	Dir string
+	// MemorySize caps the number of entries kept by the in-memory LRU. Used
+	// only when Dir is empty. Defaults to 1024.
+	MemorySize int
+}
+
+// New builds the Cache implementation selected by cfg.
+func New(cfg Config) (Cache, error) {
+	if cfg.Dir != "" {
+		c, err := NewDiskCache(cfg.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("error creating disk cache: %w", err)
+		}
+		return c, nil
+	}
+
+	size := cfg.MemorySize
+	if size <= 0 {
+		size = 1024
+	}
+	c, err := NewMemoryCache(size)
+	if err != nil {
+		return nil, fmt.Errorf("error creating memory cache: %w", err)
+	}
+	return c, nil
+}
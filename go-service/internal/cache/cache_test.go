@@ -0,0 +1,23 @@
+package cache
+
+import "testing"
+
+func TestNewMemoryCacheByDefault(t *testing.T) {
+	c, err := New(Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, ok := c.(*MemoryCache); !ok {
+		t.Errorf("New(Config{}) = %T, want *MemoryCache", c)
+	}
+}
+
+func TestNewDiskCacheWhenDirSet(t *testing.T) {
+	c, err := New(Config{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, ok := c.(*DiskCache); !ok {
+		t.Errorf("New(Config{Dir: ...}) = %T, want *DiskCache", c)
+	}
+}
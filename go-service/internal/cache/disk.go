@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type diskRecord struct {
+	Value     []byte
+	ETag      string
+	ExpiresAt time.Time
+}
+
+// staleGrace is how long past ExpiresAt an on-disk entry is kept around so
+// GetStale can still serve it for conditional-request revalidation, before
+// the periodic sweep reclaims it.
+const staleGrace = 24 * time.Hour
+
+// sweepInterval is how often DiskCache scans dir for entries past
+// staleGrace. Without this, entries accumulate forever: every distinct
+// key (e.g. a rendered PDF for a templateHash that's since been replaced
+// by a PUT /api/v1/template) is a file that nothing else ever removes.
+const sweepInterval = time.Hour
+
+// DiskCache is a gob-encoded, file-per-entry Cache backed by a directory on
+// disk, so cached values survive a service restart. A background goroutine
+// periodically sweeps dir to bound its growth; see sweep.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache builds a DiskCache rooted at dir, creating it if needed, and
+// starts its background sweep.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating cache directory %s: %w", dir, err)
+	}
+	d := &DiskCache{dir: dir}
+	go d.sweepLoop()
+	return d, nil
+}
+
+func (d *DiskCache) sweepLoop() {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		d.sweep()
+	}
+}
+
+// sweep removes on-disk entries whose staleGrace has elapsed, so CACHE_DIR
+// doesn't grow without bound over a long-running service's lifetime.
+func (d *DiskCache) sweep() {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		log.Printf("Error listing cache directory %s: %v", d.dir, err)
+		return
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(d.dir, entry.Name())
+		rec, ok := d.readFile(path)
+		if !ok || now.After(rec.ExpiresAt.Add(staleGrace)) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Printf("Error removing cache entry %s: %v", path, err)
+			}
+		}
+	}
+}
+
+func (d *DiskCache) Get(key string) ([]byte, string, bool) {
+	rec, ok := d.read(key)
+	if !ok || time.Now().After(rec.ExpiresAt) {
+		return nil, "", false
+	}
+	return rec.Value, rec.ETag, true
+}
+
+func (d *DiskCache) GetStale(key string) ([]byte, string, bool) {
+	rec, ok := d.read(key)
+	if !ok {
+		return nil, "", false
+	}
+	return rec.Value, rec.ETag, true
+}
+
+func (d *DiskCache) read(key string) (diskRecord, bool) {
+	return d.readFile(d.path(key))
+}
+
+func (d *DiskCache) readFile(path string) (diskRecord, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return diskRecord{}, false
+	}
+
+	var rec diskRecord
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&rec); err != nil {
+		return diskRecord{}, false
+	}
+
+	return rec, true
+}
+
+func (d *DiskCache) Set(key string, value []byte, etag string, ttl time.Duration) {
+	rec := diskRecord{Value: value, ETag: etag, ExpiresAt: time.Now().Add(ttl)}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(rec); err != nil {
+		log.Printf("Error encoding cache entry for %s: %v", key, err)
+		return
+	}
+
+	if err := os.WriteFile(d.path(key), buf.Bytes(), 0o644); err != nil {
+		log.Printf("Error writing cache entry for %s: %v", key, err)
+	}
+}
+
+// path maps a cache key to a file under dir, hashing it so arbitrary keys
+// are safe to use as filenames.
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".gob")
+}
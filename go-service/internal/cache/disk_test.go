@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiskCacheGetSetRoundTrip(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	c.Set("k", []byte("v"), "etag-1", time.Minute)
+
+	value, etag, ok := c.Get("k")
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	if string(value) != "v" || etag != "etag-1" {
+		t.Errorf("Get() = (%q, %q), want (%q, %q)", value, etag, "v", "etag-1")
+	}
+}
+
+func TestDiskCacheGetMissingKey(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	if _, _, ok := c.Get("missing"); ok {
+		t.Error("Get() ok = true for missing key, want false")
+	}
+}
+
+func TestDiskCacheGetExpiredEntry(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	c.Set("k", []byte("v"), "etag-1", -time.Minute)
+
+	if _, _, ok := c.Get("k"); ok {
+		t.Error("Get() ok = true for expired entry, want false")
+	}
+}
+
+func TestDiskCacheGetStaleSurvivesExpiry(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	c.Set("k", []byte("v"), "etag-1", -time.Minute)
+
+	value, etag, ok := c.GetStale("k")
+	if !ok {
+		t.Fatal("GetStale() ok = false for expired entry, want true")
+	}
+	if string(value) != "v" || etag != "etag-1" {
+		t.Errorf("GetStale() = (%q, %q), want (%q, %q)", value, etag, "v", "etag-1")
+	}
+}
+
+func TestDiskCacheSweepRemovesEntriesPastStaleGrace(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	// Expired long enough ago that staleGrace has also elapsed.
+	c.Set("old", []byte("v"), "etag-1", -(staleGrace + time.Minute))
+	// Expired, but still within staleGrace.
+	c.Set("recent", []byte("v"), "etag-2", -time.Minute)
+	// Not expired at all.
+	c.Set("fresh", []byte("v"), "etag-3", time.Minute)
+
+	c.sweep()
+
+	if _, _, ok := c.GetStale("old"); ok {
+		t.Error("GetStale(\"old\") ok = true after sweep, want false")
+	}
+	if _, _, ok := c.GetStale("recent"); !ok {
+		t.Error("GetStale(\"recent\") ok = false after sweep, want true")
+	}
+	if _, _, ok := c.GetStale("fresh"); !ok {
+		t.Error("GetStale(\"fresh\") ok = false after sweep, want true")
+	}
+}
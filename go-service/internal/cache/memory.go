@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+type memoryEntry struct {
+	value     []byte
+	etag      string
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-memory, size-bounded LRU Cache.
+type MemoryCache struct {
+	lru *lru.Cache[string, memoryEntry]
+}
+
+// NewMemoryCache builds a MemoryCache holding at most size entries.
+func NewMemoryCache(size int) (*MemoryCache, error) {
+	l, err := lru.New[string, memoryEntry](size)
+	if err != nil {
+		return nil, err
+	}
+	return &MemoryCache{lru: l}, nil
+}
+
+func (m *MemoryCache) Get(key string) ([]byte, string, bool) {
+	entry, ok := m.lru.Get(key)
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, "", false
+	}
+	return entry.value, entry.etag, true
+}
+
+func (m *MemoryCache) GetStale(key string) ([]byte, string, bool) {
+	entry, ok := m.lru.Get(key)
+	if !ok {
+		return nil, "", false
+	}
+	return entry.value, entry.etag, true
+}
+
+func (m *MemoryCache) Set(key string, value []byte, etag string, ttl time.Duration) {
+	m.lru.Add(key, memoryEntry{value: value, etag: etag, expiresAt: time.Now().Add(ttl)})
+}
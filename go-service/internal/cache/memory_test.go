@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheGetSetRoundTrip(t *testing.T) {
+	c, err := NewMemoryCache(8)
+	if err != nil {
+		t.Fatalf("NewMemoryCache() error = %v", err)
+	}
+
+	c.Set("k", []byte("v"), "etag-1", time.Minute)
+
+	value, etag, ok := c.Get("k")
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	if string(value) != "v" || etag != "etag-1" {
+		t.Errorf("Get() = (%q, %q), want (%q, %q)", value, etag, "v", "etag-1")
+	}
+}
+
+func TestMemoryCacheGetMissingKey(t *testing.T) {
+	c, err := NewMemoryCache(8)
+	if err != nil {
+		t.Fatalf("NewMemoryCache() error = %v", err)
+	}
+
+	if _, _, ok := c.Get("missing"); ok {
+		t.Error("Get() ok = true for missing key, want false")
+	}
+}
+
+func TestMemoryCacheGetExpiredEntry(t *testing.T) {
+	c, err := NewMemoryCache(8)
+	if err != nil {
+		t.Fatalf("NewMemoryCache() error = %v", err)
+	}
+
+	c.Set("k", []byte("v"), "etag-1", -time.Minute)
+
+	if _, _, ok := c.Get("k"); ok {
+		t.Error("Get() ok = true for expired entry, want false")
+	}
+}
+
+func TestMemoryCacheGetStaleSurvivesExpiry(t *testing.T) {
+	c, err := NewMemoryCache(8)
+	if err != nil {
+		t.Fatalf("NewMemoryCache() error = %v", err)
+	}
+
+	c.Set("k", []byte("v"), "etag-1", -time.Minute)
+
+	value, etag, ok := c.GetStale("k")
+	if !ok {
+		t.Fatal("GetStale() ok = false for expired entry, want true")
+	}
+	if string(value) != "v" || etag != "etag-1" {
+		t.Errorf("GetStale() = (%q, %q), want (%q, %q)", value, etag, "v", "etag-1")
+	}
+}
+
+func TestMemoryCacheEvictsBeyondSize(t *testing.T) {
+	c, err := NewMemoryCache(2)
+	if err != nil {
+		t.Fatalf("NewMemoryCache() error = %v", err)
+	}
+
+	c.Set("a", []byte("1"), "", time.Minute)
+	c.Set("b", []byte("2"), "", time.Minute)
+	c.Set("c", []byte("3"), "", time.Minute)
+
+	if _, _, ok := c.GetStale("a"); ok {
+		t.Error("GetStale(\"a\") ok = true after size eviction, want false")
+	}
+	if _, _, ok := c.GetStale("c"); !ok {
+		t.Error("GetStale(\"c\") ok = false, want true")
+	}
+}
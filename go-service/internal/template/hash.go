@@ -0,0 +1,20 @@
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Hash returns a stable, content-based identifier for tmpl, suitable for use
+// as part of a rendered-PDF cache key: two templates with the same fields
+// hash the same regardless of how they were loaded.
+func Hash(tmpl *ReportTemplate) (string, error) {
+	data, err := json.Marshal(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling template: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,35 @@
+package template
+
+import "testing"
+
+func TestHashStableForEquivalentTemplates(t *testing.T) {
+	a, err := Hash(Default())
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	b, err := Hash(Default())
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if a != b {
+		t.Errorf("Hash() = %q and %q, want equal for identical templates", a, b)
+	}
+}
+
+func TestHashDiffersWhenTemplateChanges(t *testing.T) {
+	base, err := Hash(Default())
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	changed := Default()
+	changed.HeaderText = "Different School"
+	other, err := Hash(changed)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	if base == other {
+		t.Errorf("Hash() = %q for both templates, want different hashes", base)
+	}
+}
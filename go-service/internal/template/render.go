@@ -0,0 +1,178 @@
+package template
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"github.com/jon-deo/Tasks/go-service/internal/backend"
+)
+
+// Render walks tmpl and emits the corresponding gofpdf calls for student,
+// producing the final PDF bytes.
+func Render(student *backend.StudentDetail, tmpl *ReportTemplate) ([]byte, error) {
+	pageSize := tmpl.PageSize
+	if pageSize == "" {
+		pageSize = "A4"
+	}
+
+	pdf := gofpdf.New("P", "mm", pageSize, "")
+	pdf.AddPage()
+
+	if tmpl.LogoPNGBase64 != "" {
+		if err := addLogo(pdf, tmpl.LogoPNGBase64); err != nil {
+			return nil, fmt.Errorf("error embedding logo: %w", err)
+		}
+	}
+
+	setFont(pdf, tmpl.TitleFont)
+	pdf.Cell(40, 10, "Student Report")
+	pdf.Ln(15)
+
+	setFont(pdf, tmpl.HeadingFont)
+	pdf.Cell(40, 10, tmpl.HeaderText)
+	pdf.Ln(10)
+
+	pdf.SetFont("Arial", "I", 10)
+	pdf.Cell(40, 10, fmt.Sprintf("Generated on: %s", time.Now().Format("January 2, 2006")))
+	pdf.Ln(15)
+
+	for _, section := range tmpl.Sections {
+		setFont(pdf, tmpl.HeadingFont)
+		pdf.Cell(40, 10, section.Title)
+		pdf.Ln(10)
+
+		for _, field := range section.Fields {
+			value := formatValue(field.Formatter, studentField(student, field.Source))
+			addInfoRow(pdf, tmpl, field.Label, value)
+		}
+		pdf.Ln(10)
+	}
+
+	pdf.SetY(-30)
+	setFont(pdf, tmpl.FooterFont)
+	pdf.Cell(0, 10, tmpl.FooterText)
+	pdf.Ln(5)
+	pdf.Cell(0, 10, fmt.Sprintf("Report generated by: %s", student.ReporterName))
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func addLogo(pdf *gofpdf.Fpdf, logoPNGBase64 string) error {
+	raw, err := base64.StdEncoding.DecodeString(logoPNGBase64)
+	if err != nil {
+		return fmt.Errorf("error decoding base64 logo: %w", err)
+	}
+
+	pdf.RegisterImageOptionsReader("report-logo", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(raw))
+	pdf.ImageOptions("report-logo", 170, 10, 25, 0, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	return nil
+}
+
+func setFont(pdf *gofpdf.Fpdf, font Font) {
+	pdf.SetFont(font.Family, font.Style, font.Size)
+}
+
+func addInfoRow(pdf *gofpdf.Fpdf, tmpl *ReportTemplate, label, value string) {
+	setFont(pdf, tmpl.LabelFont)
+	pdf.Cell(40, 8, label+":")
+	setFont(pdf, tmpl.ValueFont)
+	pdf.Cell(0, 8, value)
+	pdf.Ln(8)
+}
+
+// studentField looks up a student value by its template source key.
+func studentField(student *backend.StudentDetail, source string) string {
+	switch source {
+	case "id":
+		return fmt.Sprintf("%d", student.ID)
+	case "name":
+		return student.Name
+	case "email":
+		return student.Email
+	case "phone":
+		return student.Phone
+	case "gender":
+		return student.Gender
+	case "dob":
+		return student.DOB
+	case "class":
+		return student.Class
+	case "section":
+		return student.Section
+	case "roll":
+		return student.Roll
+	case "fatherName":
+		return student.FatherName
+	case "fatherPhone":
+		return student.FatherPhone
+	case "motherName":
+		return student.MotherName
+	case "motherPhone":
+		return student.MotherPhone
+	case "guardianName":
+		return student.GuardianName
+	case "guardianPhone":
+		return student.GuardianPhone
+	case "relationOfGuardian":
+		return student.RelationOfGuardian
+	case "currentAddress":
+		return student.CurrentAddress
+	case "permanentAddress":
+		return student.PermanentAddress
+	case "admissionDate":
+		return student.AdmissionDate
+	case "reporterName":
+		return student.ReporterName
+	default:
+		return ""
+	}
+}
+
+// formatValue applies an optional named formatter to a raw field value.
+func formatValue(formatter, value string) string {
+	switch formatter {
+	case "date":
+		return formatDate(value)
+	case "phone":
+		return formatPhone(value)
+	default:
+		return value
+	}
+}
+
+// formatDate reformats an ISO-8601 date (as returned by the backend) into a
+// human-readable form, leaving the value untouched if it doesn't parse.
+func formatDate(value string) string {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("January 2, 2006")
+		}
+	}
+	return value
+}
+
+// formatPhone groups a plain 10-digit phone number as "XXX-XXX-XXXX",
+// leaving anything else (already formatted, international, blank) untouched.
+func formatPhone(value string) string {
+	digits := strings.TrimSpace(value)
+	if len(digits) != 10 {
+		return value
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return value
+		}
+	}
+	return fmt.Sprintf("%s-%s-%s", digits[0:3], digits[3:6], digits[6:10])
+}
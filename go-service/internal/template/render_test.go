@@ -0,0 +1,67 @@
+package template
+
+import "testing"
+
+func TestFormatDateISO(t *testing.T) {
+	got := formatDate("2010-05-03")
+	want := "May 3, 2010"
+	if got != want {
+		t.Errorf("formatDate() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatDateRFC3339(t *testing.T) {
+	got := formatDate("2010-05-03T00:00:00Z")
+	want := "May 3, 2010"
+	if got != want {
+		t.Errorf("formatDate() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatDateUnparseableLeftUntouched(t *testing.T) {
+	got := formatDate("not-a-date")
+	if got != "not-a-date" {
+		t.Errorf("formatDate() = %q, want unchanged value", got)
+	}
+}
+
+func TestFormatPhoneTenDigits(t *testing.T) {
+	got := formatPhone("5551234567")
+	want := "555-123-4567"
+	if got != want {
+		t.Errorf("formatPhone() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatPhoneWrongLengthLeftUntouched(t *testing.T) {
+	got := formatPhone("12345")
+	if got != "12345" {
+		t.Errorf("formatPhone() = %q, want unchanged value", got)
+	}
+}
+
+func TestFormatPhoneNonDigitsLeftUntouched(t *testing.T) {
+	got := formatPhone("555-123-4567")
+	if got != "555-123-4567" {
+		t.Errorf("formatPhone() = %q, want unchanged value", got)
+	}
+}
+
+func TestFormatValueNoFormatterPassesThrough(t *testing.T) {
+	got := formatValue("", "5551234567")
+	if got != "5551234567" {
+		t.Errorf("formatValue() = %q, want unchanged value", got)
+	}
+}
+
+func TestDefaultTemplateHasNoFormatters(t *testing.T) {
+	// Default() must reproduce the service's original layout, which printed
+	// dob/admissionDate/phone fields verbatim with no reformatting.
+	for _, section := range Default().Sections {
+		for _, field := range section.Fields {
+			if field.Formatter != "" {
+				t.Errorf("Default() field %q has formatter %q, want none", field.Source, field.Formatter)
+			}
+		}
+	}
+}
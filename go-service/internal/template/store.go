@@ -0,0 +1,162 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// Store caches the active ReportTemplate and hot-reloads it from disk when
+// the backing file changes, so schools can customize their report layout
+// without redeploying the service.
+type Store struct {
+	path string
+
+	mu      sync.RWMutex
+	current *ReportTemplate
+
+	watcher *fsnotify.Watcher
+}
+
+// NewStore loads the template at path (YAML or JSON, inferred from
+// extension) and watches it for changes. An empty path yields a Store
+// serving Default() with no file backing.
+func NewStore(path string) (*Store, error) {
+	s := &Store{path: path, current: Default()}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("error creating template watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("error watching template directory: %w", err)
+	}
+	s.watcher = watcher
+
+	go s.watchLoop()
+
+	return s, nil
+}
+
+func (s *Store) watchLoop() {
+	for {
+		select {
+		case event, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(s.path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := s.reload(); err != nil {
+				log.Printf("Error reloading report template %s: %v", s.path, err)
+			} else {
+				log.Printf("Reloaded report template from %s", s.path)
+			}
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("Report template watcher error: %v", err)
+		}
+	}
+}
+
+func (s *Store) reload() error {
+	tmpl, err := load(s.path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.current = tmpl
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Current returns the currently active template.
+func (s *Store) Current() *ReportTemplate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// Set replaces the active template and, if the Store is backed by a file,
+// persists it so the change survives a restart.
+func (s *Store) Set(tmpl *ReportTemplate) error {
+	if s.path != "" {
+		if err := save(s.path, tmpl); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	s.current = tmpl
+	s.mu.Unlock()
+
+	return nil
+}
+
+func load(path string) (*ReportTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading template file: %w", err)
+	}
+
+	var tmpl ReportTemplate
+	if isYAML(path) {
+		if err := yaml.Unmarshal(data, &tmpl); err != nil {
+			return nil, fmt.Errorf("error parsing YAML template: %w", err)
+		}
+	} else {
+		if err := json.Unmarshal(data, &tmpl); err != nil {
+			return nil, fmt.Errorf("error parsing JSON template: %w", err)
+		}
+	}
+
+	return &tmpl, nil
+}
+
+func save(path string, tmpl *ReportTemplate) error {
+	var data []byte
+	var err error
+	if isYAML(path) {
+		data, err = yaml.Marshal(tmpl)
+	} else {
+		data, err = json.MarshalIndent(tmpl, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("error encoding template: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing template file: %w", err)
+	}
+
+	return nil
+}
+
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
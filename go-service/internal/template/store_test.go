@@ -0,0 +1,87 @@
+package template
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewStoreEmptyPathServesDefault(t *testing.T) {
+	s, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	if s.Current().HeaderText != Default().HeaderText {
+		t.Errorf("Current().HeaderText = %q, want %q", s.Current().HeaderText, Default().HeaderText)
+	}
+}
+
+func TestStoreSetAndReloadJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "template.json")
+
+	s, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	s.path = path
+
+	tmpl := Default()
+	tmpl.HeaderText = "Greenwood High"
+	if err := s.Set(tmpl); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	loaded, err := load(path)
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	if loaded.HeaderText != "Greenwood High" {
+		t.Errorf("load().HeaderText = %q, want %q", loaded.HeaderText, "Greenwood High")
+	}
+}
+
+func TestStoreHotReloadsOnWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "template.yaml")
+
+	tmpl := Default()
+	tmpl.HeaderText = "Initial"
+	if err := save(path, tmpl); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	s, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	if s.Current().HeaderText != "Initial" {
+		t.Fatalf("Current().HeaderText = %q, want %q", s.Current().HeaderText, "Initial")
+	}
+
+	tmpl.HeaderText = "Updated"
+	if err := save(path, tmpl); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if s.Current().HeaderText == "Updated" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("Current().HeaderText = %q, want %q after hot-reload", s.Current().HeaderText, "Updated")
+}
+
+func TestIsYAML(t *testing.T) {
+	cases := map[string]bool{
+		"template.yaml": true,
+		"template.yml":  true,
+		"template.json": false,
+	}
+	for path, want := range cases {
+		if got := isYAML(path); got != want {
+			t.Errorf("isYAML(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Package template describes the layout of a generated student report as
+// data, so schools can customize fonts, sections, and field ordering without
+// redeploying the service.
+package template
+
+// Font describes a gofpdf font selection.
+type Font struct {
+	Family string  `yaml:"family" json:"family"`
+	Style  string  `yaml:"style" json:"style"`
+	Size   float64 `yaml:"size" json:"size"`
+}
+
+// Field is a single label/value row rendered inside a Section. Source is the
+// key used to look up the value on the student (see studentField), and
+// Formatter optionally post-processes it ("date", "phone", or "" for
+// pass-through).
+type Field struct {
+	Label     string `yaml:"label" json:"label"`
+	Source    string `yaml:"source" json:"source"`
+	Formatter string `yaml:"formatter,omitempty" json:"formatter,omitempty"`
+}
+
+// Section is a titled group of fields, e.g. "Personal Information".
+type Section struct {
+	Title  string  `yaml:"title" json:"title"`
+	Fields []Field `yaml:"fields" json:"fields"`
+}
+
+// ReportTemplate is the full description of a rendered report: page setup,
+// fonts, an optional logo, and the ordered sections of student fields.
+type ReportTemplate struct {
+	PageSize   string `yaml:"pageSize" json:"pageSize"`
+	HeaderText string `yaml:"headerText" json:"headerText"`
+	FooterText string `yaml:"footerText" json:"footerText"`
+
+	// LogoPNGBase64 is an optional base64-encoded PNG rendered in the page
+	// header.
+	LogoPNGBase64 string `yaml:"logoPngBase64,omitempty" json:"logoPngBase64,omitempty"`
+
+	TitleFont   Font `yaml:"titleFont" json:"titleFont"`
+	HeadingFont Font `yaml:"headingFont" json:"headingFont"`
+	LabelFont   Font `yaml:"labelFont" json:"labelFont"`
+	ValueFont   Font `yaml:"valueFont" json:"valueFont"`
+	FooterFont  Font `yaml:"footerFont" json:"footerFont"`
+
+	Sections []Section `yaml:"sections" json:"sections"`
+}
+
+// Default returns the template that reproduces the service's original,
+// hardcoded report layout, with one difference: Render sets HeaderText in
+// HeadingFont, so the school-name line prints at 12pt where the original
+// layout used 14pt. It's used whenever no REPORT_TEMPLATE is configured.
+func Default() *ReportTemplate {
+	return &ReportTemplate{
+		PageSize:    "A4",
+		HeaderText:  "School Management System",
+		FooterText:  "This is an official document of School Management System",
+		TitleFont:   Font{Family: "Arial", Style: "B", Size: 16},
+		HeadingFont: Font{Family: "Arial", Style: "B", Size: 12},
+		LabelFont:   Font{Family: "Arial", Style: "B", Size: 10},
+		ValueFont:   Font{Family: "Arial", Style: "", Size: 10},
+		FooterFont:  Font{Family: "Arial", Style: "I", Size: 8},
+		Sections: []Section{
+			{
+				Title: "Personal Information",
+				Fields: []Field{
+					{Label: "ID", Source: "id"},
+					{Label: "Name", Source: "name"},
+					{Label: "Email", Source: "email"},
+					{Label: "Phone", Source: "phone"},
+					{Label: "Gender", Source: "gender"},
+					{Label: "Date of Birth", Source: "dob"},
+					{Label: "Admission Date", Source: "admissionDate"},
+				},
+			},
+			{
+				Title: "Academic Information",
+				Fields: []Field{
+					{Label: "Class", Source: "class"},
+					{Label: "Section", Source: "section"},
+					{Label: "Roll Number", Source: "roll"},
+				},
+			},
+			{
+				Title: "Family Information",
+				Fields: []Field{
+					{Label: "Father's Name", Source: "fatherName"},
+					{Label: "Father's Phone", Source: "fatherPhone"},
+					{Label: "Mother's Name", Source: "motherName"},
+					{Label: "Mother's Phone", Source: "motherPhone"},
+					{Label: "Guardian's Name", Source: "guardianName"},
+					{Label: "Guardian's Phone", Source: "guardianPhone"},
+					{Label: "Relation of Guardian", Source: "relationOfGuardian"},
+				},
+			},
+			{
+				Title: "Address Information",
+				Fields: []Field{
+					{Label: "Current Address", Source: "currentAddress"},
+					{Label: "Permanent Address", Source: "permanentAddress"},
+				},
+			},
+		},
+	}
+}
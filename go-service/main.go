@@ -1,58 +1,32 @@
 package main
 
 import (
-	"bytes"
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
-	"github.com/jung-kurt/gofpdf"
-)
-
-// StudentDetail represents the student data structure from the Node.js API
-type StudentDetail struct {
-	ID                 int    `json:"id"`
-	Name               string `json:"name"`
-	Email              string `json:"email"`
-	SystemAccess       bool   `json:"systemAccess"`
-	Phone              string `json:"phone"`
-	Gender             string `json:"gender"`
-	DOB                string `json:"dob"`
-	Class              string `json:"class"`
-	Section            string `json:"section"`
-	Roll               string `json:"roll"`
-	FatherName         string `json:"fatherName"`
-	FatherPhone        string `json:"fatherPhone"`
-	MotherName         string `json:"motherName"`
-	MotherPhone        string `json:"motherPhone"`
-	GuardianName       string `json:"guardianName"`
-	GuardianPhone      string `json:"guardianPhone"`
-	RelationOfGuardian string `json:"relationOfGuardian"`
-	CurrentAddress     string `json:"currentAddress"`
-	PermanentAddress   string `json:"permanentAddress"`
-	AdmissionDate      string `json:"admissionDate"`
-	ReporterName       string `json:"reporterName"`
-}
 
-// LoginResponse represents the response from the login API
-type LoginResponse struct {
-	AccessToken string `json:"accessToken"`
-	User        User   `json:"user"`
-}
+	"github.com/jon-deo/Tasks/go-service/internal/authn"
+	"github.com/jon-deo/Tasks/go-service/internal/backend"
+	"github.com/jon-deo/Tasks/go-service/internal/cache"
+	"github.com/jon-deo/Tasks/go-service/internal/template"
+)
 
-// User represents the user data from the login response
-type User struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-	Role string `json:"role"`
-}
+// bulkReportConcurrency caps how many student PDFs are rendered in parallel
+// for a single bulk report request.
+const bulkReportConcurrency = 8
 
 // Config holds application configuration
 type Config struct {
@@ -60,29 +34,111 @@ type Config struct {
 	BackendURL    string
 	AdminEmail    string
 	AdminPassword string
+
+	// AuthMode selects how the service authenticates with the backend:
+	// "cookie" (default), "oauth2", or "oidc". See internal/backend.
+	AuthMode     string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+
+	// ReportTemplatePath points at a YAML/JSON file describing the report
+	// layout (see internal/template). Empty means use template.Default().
+	ReportTemplatePath string
+
+	// JWKSURL is where incoming bearer JWTs are verified against (see
+	// internal/authn). Required when RequireAuth is true.
+	JWKSURL string
+	// CSRFSecret derives the expected double-submit CSRF token.
+	CSRFSecret string
+	// RequireAuth gates every route behind a valid bearer JWT. Defaults to
+	// true; set to false only for local development without a backend JWKS
+	// endpoint.
+	RequireAuth bool
+
+	// CacheDir, if set, backs the student/PDF cache with gob-encoded files
+	// on disk instead of an in-memory LRU. See internal/cache.
+	CacheDir string
+	// StudentTTL is how long a fetched student (and the PDF rendered from
+	// it) is served from cache before the backend is consulted again.
+	StudentTTL time.Duration
 }
 
-var authTokens struct {
-	accessToken  string
-	refreshToken string
-	csrfToken    string
-	lastRefresh  time.Time
+// ReportManifestEntry describes the outcome of generating a single student's
+// report as part of a bulk run, so callers can reconcile which ones succeeded.
+type ReportManifestEntry struct {
+	Hash        string    `json:"hash"`
+	GeneratedAt time.Time `json:"generatedAt"`
 }
 
+var reportManifest = struct {
+	mu      sync.Mutex
+	entries map[string]ReportManifestEntry
+}{entries: make(map[string]ReportManifestEntry)}
+
 func main() {
 	// Load environment variables
 	config := loadConfig()
 
-	// Authenticate with backend
-	authenticateWithBackend(config)
+	// Build the backend client. In cookie and oidc modes this contacts the
+	// backend now, so misconfiguration fails at startup; oauth2 mode fetches
+	// its first token on the first request.
+	client, err := backend.NewClient(backendConfig(config))
+	if err != nil {
+		log.Fatalf("Error creating backend client: %v", err)
+	}
 
-	// Create router
-	r := mux.NewRouter()
+	// Cache fetched students (and, separately, rendered PDFs) so repeat
+	// requests within StudentTTL skip the backend and the renderer entirely.
+	reportCache, err := cache.New(cache.Config{Dir: config.CacheDir})
+	if err != nil {
+		log.Fatalf("Error creating cache: %v", err)
+	}
+	client = backend.NewCachingClient(client, reportCache, config.StudentTTL)
+
+	// Load the report template, hot-reloading it if REPORT_TEMPLATE is set.
+	templates, err := template.NewStore(config.ReportTemplatePath)
+	if err != nil {
+		log.Fatalf("Error loading report template: %v", err)
+	}
+
+	// Build the JWKS-backed keyfunc used to verify incoming bearer JWTs.
+	var keyfunc jwt.Keyfunc
+	if config.RequireAuth {
+		if config.JWKSURL == "" {
+			log.Fatal("JWKS_URL must be set when REQUIRE_AUTH is true")
+		}
+		if config.CSRFSecret == "" {
+			log.Fatal("CSRF_SECRET must be set when REQUIRE_AUTH is true")
+		}
+		keyfunc, err = authn.NewJWKSKeyfunc(config.JWKSURL)
+		if err != nil {
+			log.Fatalf("Error initializing JWKS: %v", err)
+		}
+	}
 
-	// Define routes
-	r.HandleFunc("/api/v1/students/{id}/report", generateReportHandler(config)).Methods("GET")
+	// Create router. /health is mounted directly on r, unwrapped by any
+	// auth/CSRF middleware, so liveness/readiness probes never need a
+	// backend JWT.
+	r := mux.NewRouter()
 	r.HandleFunc("/health", healthCheckHandler).Methods("GET")
 
+	protected := r.NewRoute().Subrouter()
+	protected.Use(authn.RequireAuth(keyfunc, config.RequireAuth))
+	protected.Use(authn.RequireCSRF(config.CSRFSecret))
+	protected.HandleFunc("/api/v1/students/{id}/report", generateReportHandler(client, templates, reportCache, config.StudentTTL)).Methods("GET")
+
+	// The bulk/status/template routes have no {id} path variable for
+	// RequireAuth to match against a student's own subject claim, so they
+	// need their own staff-only gate instead of falling through it.
+	staff := protected.NewRoute().Subrouter()
+	staff.Use(authn.RequireStaff(config.RequireAuth))
+	staff.HandleFunc("/api/v1/students/reports", generateBulkReportHandler(client, templates, reportCache, config.StudentTTL)).Methods("GET")
+	staff.HandleFunc("/api/v1/students/reports/status.json", reportStatusHandler).Methods("GET")
+	staff.HandleFunc("/api/v1/template", getTemplateHandler(templates)).Methods("GET")
+	staff.HandleFunc("/api/v1/template", putTemplateHandler(templates, config.RequireAuth)).Methods("PUT")
+
 	// Start server
 	port := config.Port
 	log.Printf("Server starting on port %s...", port)
@@ -99,11 +155,43 @@ func loadConfig() Config {
 		BackendURL:    getEnv("BACKEND_URL", "http://localhost:5007/api/v1"),
 		AdminEmail:    getEnv("ADMIN_EMAIL", "admin@school-admin.com"),
 		AdminPassword: getEnv("ADMIN_PASSWORD", "3OU4zn3q6Zh9"),
+		AuthMode:      getEnv("AUTH_MODE", "cookie"),
+		IssuerURL:     getEnv("ISSUER_URL", ""),
+		ClientID:      getEnv("CLIENT_ID", ""),
+		ClientSecret:  getEnv("CLIENT_SECRET", ""),
+
+		ReportTemplatePath: getEnv("REPORT_TEMPLATE", ""),
+
+		JWKSURL:     getEnv("JWKS_URL", ""),
+		CSRFSecret:  getEnv("CSRF_SECRET", ""),
+		RequireAuth: getEnvBool("REQUIRE_AUTH", true),
+
+		CacheDir:   getEnv("CACHE_DIR", ""),
+		StudentTTL: getEnvDuration("STUDENT_TTL", 5*time.Minute),
+	}
+
+	if scopes := getEnv("SCOPES", ""); scopes != "" {
+		config.Scopes = strings.Split(scopes, ",")
 	}
 
 	return config
 }
 
+// backendConfig translates the service-level Config into the backend
+// package's Config, which only carries what a Client needs to authenticate.
+func backendConfig(config Config) backend.Config {
+	return backend.Config{
+		BackendURL:    config.BackendURL,
+		AuthMode:      backend.AuthMode(config.AuthMode),
+		AdminEmail:    config.AdminEmail,
+		AdminPassword: config.AdminPassword,
+		IssuerURL:     config.IssuerURL,
+		ClientID:      config.ClientID,
+		ClientSecret:  config.ClientSecret,
+		Scopes:        config.Scopes,
+	}
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -112,54 +200,28 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
-func authenticateWithBackend(config Config) {
-	// Create HTTP client with timeout
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	// Prepare login request
-	loginURL := fmt.Sprintf("%s/auth/login", config.BackendURL)
-	loginData := map[string]string{
-		"username": config.AdminEmail,
-		"password": config.AdminPassword,
-	}
-	loginJSON, err := json.Marshal(loginData)
-	if err != nil {
-		log.Fatalf("Error preparing login request: %v", err)
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
 	}
-
-	// Make login request
-	resp, err := client.Post(loginURL, "application/json", bytes.NewBuffer(loginJSON))
+	parsed, err := strconv.ParseBool(value)
 	if err != nil {
-		log.Fatalf("Error authenticating with backend: %v", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		log.Fatalf("Backend authentication failed with status %d: %s", resp.StatusCode, body)
+		return defaultValue
 	}
+	return parsed
+}
 
-	// Extract cookies for future requests
-	for _, cookie := range resp.Cookies() {
-		if cookie.Name == "accessToken" {
-			authTokens.accessToken = cookie.Value
-		} else if cookie.Name == "refreshToken" {
-			authTokens.refreshToken = cookie.Value
-		} else if cookie.Name == "csrfToken" {
-			authTokens.csrfToken = cookie.Value
-		}
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
 	}
-
-	// Parse response body to get access token
-	var loginResponse LoginResponse
-	if err := json.NewDecoder(resp.Body).Decode(&loginResponse); err != nil {
-		log.Fatalf("Error parsing login response: %v", err)
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
 	}
-
-	log.Printf("Successfully authenticated with backend as %s (role: %s)",
-		loginResponse.User.Name, loginResponse.User.Role)
-	authTokens.lastRefresh = time.Now()
+	return parsed
 }
 
 func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
@@ -167,7 +229,11 @@ func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
 
-func generateReportHandler(config Config) http.HandlerFunc {
+// generateReportHandler renders (or serves from cache) a single student's
+// PDF report. Rendered PDFs are cached by (studentID, templateHash) for ttl,
+// and the response carries an ETag so a client holding a cached copy can
+// revalidate with If-None-Match instead of re-downloading it.
+func generateReportHandler(client backend.Client, templates *template.Store, reportCache cache.Cache, ttl time.Duration) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Extract student ID from URL
 		vars := mux.Vars(r)
@@ -180,19 +246,41 @@ func generateReportHandler(config Config) http.HandlerFunc {
 			return
 		}
 
-		// Fetch student data from backend API
-		student, err := fetchStudentData(config.BackendURL, studentID)
+		tmpl := templates.Current()
+		templateHash, err := template.Hash(tmpl)
 		if err != nil {
-			log.Printf("Error fetching student data: %v", err)
-			http.Error(w, "Error fetching student data", http.StatusInternalServerError)
+			log.Printf("Error hashing report template: %v", err)
+			http.Error(w, "Error generating PDF", http.StatusInternalServerError)
 			return
 		}
+		cacheKey := reportCacheKey(studentID, templateHash)
+
+		pdfBytes, etag, ok := reportCache.Get(cacheKey)
+		if !ok {
+			// Fetch student data from backend API
+			student, err := client.FetchStudent(studentID)
+			if err != nil {
+				log.Printf("Error fetching student data: %v", err)
+				http.Error(w, "Error fetching student data", http.StatusInternalServerError)
+				return
+			}
+
+			// Generate PDF
+			pdfBytes, err = template.Render(student, tmpl)
+			if err != nil {
+				log.Printf("Error generating PDF: %v", err)
+				http.Error(w, "Error generating PDF", http.StatusInternalServerError)
+				return
+			}
+
+			hash := sha256.Sum256(pdfBytes)
+			etag = hex.EncodeToString(hash[:])
+			reportCache.Set(cacheKey, pdfBytes, etag, ttl)
+		}
 
-		// Generate PDF
-		pdfBytes, err := generatePDF(student)
-		if err != nil {
-			log.Printf("Error generating PDF: %v", err)
-			http.Error(w, "Error generating PDF", http.StatusInternalServerError)
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
 			return
 		}
 
@@ -203,172 +291,161 @@ func generateReportHandler(config Config) http.HandlerFunc {
 	}
 }
 
-func fetchStudentData(backendURL, studentID string) (*StudentDetail, error) {
-	// Create HTTP client with timeout
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	// Check if tokens need refresh (tokens expire after 15 minutes)
-	if time.Since(authTokens.lastRefresh) > 14*time.Minute {
-		log.Println("Auth tokens might be expired, refreshing...")
-		config := loadConfig()
-		authenticateWithBackend(config)
-	}
+// reportCacheKey identifies a rendered PDF by the student it covers and the
+// template it was rendered with, so changing the template invalidates the
+// cache without needing an explicit purge.
+func reportCacheKey(studentID, templateHash string) string {
+	return fmt.Sprintf("report:%s:%s", studentID, templateHash)
+}
 
-	// Make request to backend API
-	url := fmt.Sprintf("%s/students/%s", backendURL, studentID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
+// generateBulkReportHandler streams a ZIP archive containing one PDF report
+// per student, optionally filtered by class/section. PDFs are rendered
+// concurrently (bounded by bulkReportConcurrency) and written to the archive
+// as they complete so memory usage doesn't grow with the size of the class.
+func generateBulkReportHandler(client backend.Client, templates *template.Store, reportCache cache.Cache, ttl time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		class := r.URL.Query().Get("class")
+		section := r.URL.Query().Get("section")
 
-	// Add cookies for authentication
-	req.AddCookie(&http.Cookie{Name: "accessToken", Value: authTokens.accessToken})
-	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: authTokens.refreshToken})
-	req.AddCookie(&http.Cookie{Name: "csrfToken", Value: authTokens.csrfToken})
-	
-	// Add CSRF token to headers
-	req.Header.Set("X-CSRF-Token", authTokens.csrfToken)
+		students, err := client.FetchStudents(class, section)
+		if err != nil {
+			log.Printf("Error fetching student list: %v", err)
+			http.Error(w, "Error fetching student list", http.StatusInternalServerError)
+			return
+		}
 
-	// Execute request
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error making request to backend: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode == http.StatusUnauthorized {
-		// Try to refresh tokens and retry the request once
-		log.Println("Received 401 Unauthorized, attempting to refresh tokens and retry...")
-		config := loadConfig()
-		authenticateWithBackend(config)
-
-		// Retry the request with new tokens
-		req, _ = http.NewRequest("GET", url, nil)
-		req.AddCookie(&http.Cookie{Name: "accessToken", Value: authTokens.accessToken})
-		req.AddCookie(&http.Cookie{Name: "refreshToken", Value: authTokens.refreshToken})
-		req.AddCookie(&http.Cookie{Name: "csrfToken", Value: authTokens.csrfToken})
-		
-		// Add CSRF token to headers
-		req.Header.Set("X-CSRF-Token", authTokens.csrfToken)
-
-		resp, err = client.Do(req)
+		tmpl := templates.Current()
+		templateHash, err := template.Hash(tmpl)
 		if err != nil {
-			return nil, fmt.Errorf("error making request to backend after token refresh: %w", err)
+			log.Printf("Error hashing report template: %v", err)
+			http.Error(w, "Error generating reports", http.StatusInternalServerError)
+			return
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("backend API returned non-200 status after token refresh: %d, body: %s", resp.StatusCode, body)
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", "attachment; filename=student_reports.zip")
+
+		zw := zip.NewWriter(w)
+		defer zw.Close()
+
+		var (
+			manifestMu sync.Mutex
+			manifest   = make(map[string]ReportManifestEntry)
+			zipMu      sync.Mutex
+			wg         sync.WaitGroup
+			sem        = make(chan struct{}, bulkReportConcurrency)
+		)
+
+		for _, student := range students {
+			student := student
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				studentID := strconv.Itoa(student.ID)
+				cacheKey := reportCacheKey(studentID, templateHash)
+
+				pdfBytes, etag, ok := reportCache.Get(cacheKey)
+				if !ok {
+					detail, err := client.FetchStudent(studentID)
+					if err != nil {
+						log.Printf("Error fetching student %d: %v", student.ID, err)
+						return
+					}
+
+					pdfBytes, err = template.Render(detail, tmpl)
+					if err != nil {
+						log.Printf("Error generating PDF for student %d: %v", student.ID, err)
+						return
+					}
+
+					sum := sha256.Sum256(pdfBytes)
+					etag = hex.EncodeToString(sum[:])
+					reportCache.Set(cacheKey, pdfBytes, etag, ttl)
+				}
+
+				zipMu.Lock()
+				defer zipMu.Unlock()
+
+				entry, err := zw.Create(fmt.Sprintf("student_%d_report.pdf", student.ID))
+				if err != nil {
+					log.Printf("Error creating zip entry for student %d: %v", student.ID, err)
+					return
+				}
+				if _, err := entry.Write(pdfBytes); err != nil {
+					log.Printf("Error writing zip entry for student %d: %v", student.ID, err)
+					return
+				}
+
+				manifestMu.Lock()
+				manifest[strconv.Itoa(student.ID)] = ReportManifestEntry{
+					Hash:        etag,
+					GeneratedAt: time.Now(),
+				}
+				manifestMu.Unlock()
+			}()
 		}
-	} else if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("student with ID %s not found", studentID)
-	} else if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("backend API returned non-200 status: %d, body: %s", resp.StatusCode, body)
-	}
 
-	// Parse response body
-	var student StudentDetail
-	if err := json.NewDecoder(resp.Body).Decode(&student); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+		wg.Wait()
+
+		// Publish this run's manifest as a single atomic replace, so a
+		// second bulk request running concurrently can't wipe entries this
+		// run already wrote, and this run can't land its entries in what a
+		// caller now believes is a different run's manifest.
+		reportManifest.mu.Lock()
+		reportManifest.entries = manifest
+		reportManifest.mu.Unlock()
 	}
+}
 
-	return &student, nil
+// reportStatusHandler returns a JSON manifest of the reports produced by the
+// most recent bulk run, keyed by student ID, so a caller can reconcile which
+// reports actually succeeded.
+func reportStatusHandler(w http.ResponseWriter, r *http.Request) {
+	reportManifest.mu.Lock()
+	defer reportManifest.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reportManifest.entries)
 }
 
-func generatePDF(student *StudentDetail) ([]byte, error) {
-	// Create new PDF document
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.AddPage()
-
-	// Set font
-	pdf.SetFont("Arial", "B", 16)
-
-	// Add title
-	pdf.Cell(40, 10, "Student Report")
-	pdf.Ln(15)
-
-	// Add school logo or header
-	pdf.SetFont("Arial", "B", 14)
-	pdf.Cell(40, 10, "School Management System")
-	pdf.Ln(10)
-
-	// Add generation date
-	pdf.SetFont("Arial", "I", 10)
-	pdf.Cell(40, 10, fmt.Sprintf("Generated on: %s", time.Now().Format("January 2, 2006")))
-	pdf.Ln(15)
-
-	// Add student information
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(40, 10, "Personal Information")
-	pdf.Ln(10)
-
-	// Add student details in a table-like format
-	addInfoRow(pdf, "ID", fmt.Sprintf("%d", student.ID))
-	addInfoRow(pdf, "Name", student.Name)
-	addInfoRow(pdf, "Email", student.Email)
-	addInfoRow(pdf, "Phone", student.Phone)
-	addInfoRow(pdf, "Gender", student.Gender)
-	addInfoRow(pdf, "Date of Birth", student.DOB)
-	addInfoRow(pdf, "Admission Date", student.AdmissionDate)
-	pdf.Ln(10)
-
-	// Add academic information
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(40, 10, "Academic Information")
-	pdf.Ln(10)
-
-	addInfoRow(pdf, "Class", student.Class)
-	addInfoRow(pdf, "Section", student.Section)
-	addInfoRow(pdf, "Roll Number", student.Roll)
-	pdf.Ln(10)
-
-	// Add family information
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(40, 10, "Family Information")
-	pdf.Ln(10)
-
-	addInfoRow(pdf, "Father's Name", student.FatherName)
-	addInfoRow(pdf, "Father's Phone", student.FatherPhone)
-	addInfoRow(pdf, "Mother's Name", student.MotherName)
-	addInfoRow(pdf, "Mother's Phone", student.MotherPhone)
-	addInfoRow(pdf, "Guardian's Name", student.GuardianName)
-	addInfoRow(pdf, "Guardian's Phone", student.GuardianPhone)
-	addInfoRow(pdf, "Relation of Guardian", student.RelationOfGuardian)
-	pdf.Ln(10)
-
-	// Add address information
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(40, 10, "Address Information")
-	pdf.Ln(10)
-
-	addInfoRow(pdf, "Current Address", student.CurrentAddress)
-	addInfoRow(pdf, "Permanent Address", student.PermanentAddress)
-	pdf.Ln(10)
-
-	// Add footer
-	pdf.SetY(-30)
-	pdf.SetFont("Arial", "I", 8)
-	pdf.Cell(0, 10, "This is an official document of School Management System")
-	pdf.Ln(5)
-	pdf.Cell(0, 10, fmt.Sprintf("Report generated by: %s", student.ReporterName))
-
-	// Output PDF to buffer
-	var buf bytes.Buffer
-	err := pdf.Output(&buf)
-	if err != nil {
-		return nil, err
+// getTemplateHandler returns the currently active report template as JSON.
+func getTemplateHandler(templates *template.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(templates.Current())
 	}
-
-	return buf.Bytes(), nil
 }
 
-func addInfoRow(pdf *gofpdf.Fpdf, label, value string) {
-	pdf.SetFont("Arial", "B", 10)
-	pdf.Cell(40, 8, label+":")
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(0, 8, value)
-	pdf.Ln(8)
+// putTemplateHandler replaces the active report template, persisting it if
+// the store is file-backed. Only admins may update the template; when
+// requireAuth is false (local dev, no JWKS configured) the check is skipped
+// since RequireAuth never attached claims to the request in that mode.
+func putTemplateHandler(templates *template.Store, requireAuth bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if requireAuth {
+			claims, ok := authn.ClaimsFromContext(r.Context())
+			if !ok || claims.Role != authn.RoleAdmin {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+		}
+
+		var tmpl template.ReportTemplate
+		if err := json.NewDecoder(r.Body).Decode(&tmpl); err != nil {
+			http.Error(w, "Invalid template", http.StatusBadRequest)
+			return
+		}
+
+		if err := templates.Set(&tmpl); err != nil {
+			log.Printf("Error saving report template: %v", err)
+			http.Error(w, "Error saving template", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(templates.Current())
+	}
 }
@@ -2,13 +2,100 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/mux"
+
+	"github.com/jon-deo/Tasks/go-service/internal/authn"
+	"github.com/jon-deo/Tasks/go-service/internal/backend"
+	"github.com/jon-deo/Tasks/go-service/internal/cache"
+	"github.com/jon-deo/Tasks/go-service/internal/template"
 )
 
+var testJWTSecret = []byte("test-secret")
+
+func testKeyfunc(token *jwt.Token) (interface{}, error) {
+	return testJWTSecret, nil
+}
+
+func signTestToken(t *testing.T, role, subject string) string {
+	t.Helper()
+	claims := authn.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: subject},
+		Role:             role,
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(testJWTSecret)
+	if err != nil {
+		t.Fatalf("failed to sign test token: %v", err)
+	}
+	return signed
+}
+
+// fakeBackendClient is a no-op backend.Client for tests that don't exercise
+// the backend call path (e.g. validation failures returned before any fetch).
+type fakeBackendClient struct{}
+
+func (fakeBackendClient) FetchStudent(studentID string) (*backend.StudentDetail, error) {
+	return nil, fmt.Errorf("fakeBackendClient: not implemented")
+}
+
+func (fakeBackendClient) FetchStudentETag(studentID, ifNoneMatch string) (*backend.StudentDetail, string, bool, error) {
+	return nil, "", false, fmt.Errorf("fakeBackendClient: not implemented")
+}
+
+func (fakeBackendClient) FetchStudents(class, section string) ([]backend.StudentDetail, error) {
+	return nil, fmt.Errorf("fakeBackendClient: not implemented")
+}
+
+// fakeRosterClient is a backend.Client serving a fixed, in-memory roster, for
+// tests that exercise the bulk report path end to end.
+type fakeRosterClient struct {
+	students []backend.StudentDetail
+}
+
+// blockingRosterClient wraps fakeRosterClient and blocks FetchStudent for any
+// studentID present in block until its channel is closed, so tests can force
+// a deterministic interleaving between two concurrent bulk runs.
+type blockingRosterClient struct {
+	fakeRosterClient
+	block map[string]chan struct{}
+}
+
+func (b blockingRosterClient) FetchStudent(studentID string) (*backend.StudentDetail, error) {
+	if ch, ok := b.block[studentID]; ok {
+		<-ch
+	}
+	return b.fakeRosterClient.FetchStudent(studentID)
+}
+
+func (f fakeRosterClient) FetchStudent(studentID string) (*backend.StudentDetail, error) {
+	for _, s := range f.students {
+		if strconv.Itoa(s.ID) == studentID {
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("student %s not found", studentID)
+}
+
+func (f fakeRosterClient) FetchStudentETag(studentID, ifNoneMatch string) (*backend.StudentDetail, string, bool, error) {
+	student, err := f.FetchStudent(studentID)
+	if err != nil {
+		return nil, "", false, err
+	}
+	return student, "", false, nil
+}
+
+func (f fakeRosterClient) FetchStudents(class, section string) ([]backend.StudentDetail, error) {
+	return f.students, nil
+}
+
 func TestHealthCheckHandler(t *testing.T) {
 	// Create a request to pass to our handler
 	req, err := http.NewRequest("GET", "/health", nil)
@@ -42,16 +129,28 @@ func TestHealthCheckHandler(t *testing.T) {
 	}
 }
 
-func TestGenerateReportHandlerInvalidID(t *testing.T) {
-	// Create a new router
-	r := mux.NewRouter()
+func newTestReportRouter(t *testing.T, requireAuth bool) *mux.Router {
+	t.Helper()
 
-	// Register the handler
-	config := Config{
-		Port:       "5008",
-		BackendURL: "http://localhost:5007/api/v1",
+	templates, err := template.NewStore("")
+	if err != nil {
+		t.Fatal(err)
 	}
-	r.HandleFunc("/api/v1/students/{id}/report", generateReportHandler(config))
+
+	reportCache, err := cache.New(cache.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := mux.NewRouter()
+	r.Use(authn.RequireAuth(testKeyfunc, requireAuth))
+	r.HandleFunc("/api/v1/students/{id}/report", generateReportHandler(fakeBackendClient{}, templates, reportCache, time.Minute))
+	return r
+}
+
+func TestGenerateReportHandlerInvalidID(t *testing.T) {
+	// Auth isn't under test here, so disable it to isolate ID validation.
+	r := newTestReportRouter(t, false)
 
 	// Create a request with an invalid ID
 	req, err := http.NewRequest("GET", "/api/v1/students/invalid/report", nil)
@@ -70,4 +169,344 @@ func TestGenerateReportHandlerInvalidID(t *testing.T) {
 		t.Errorf("handler returned wrong status code: got %v want %v",
 			status, http.StatusBadRequest)
 	}
+}
+
+func TestGenerateReportHandlerMissingToken(t *testing.T) {
+	r := newTestReportRouter(t, true)
+
+	req, err := http.NewRequest("GET", "/api/v1/students/1/report", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnauthorized {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnauthorized)
+	}
+}
+
+func newTestStaffRouter(t *testing.T, requireAuth bool) *mux.Router {
+	t.Helper()
+
+	templates, err := template.NewStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reportCache, err := cache.New(cache.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/health", healthCheckHandler).Methods("GET")
+
+	protected := r.NewRoute().Subrouter()
+	protected.Use(authn.RequireAuth(testKeyfunc, requireAuth))
+
+	staff := protected.NewRoute().Subrouter()
+	staff.Use(authn.RequireStaff(requireAuth))
+	staff.HandleFunc("/api/v1/students/reports", generateBulkReportHandler(fakeBackendClient{}, templates, reportCache, time.Minute))
+	staff.HandleFunc("/api/v1/students/reports/status.json", reportStatusHandler)
+	staff.HandleFunc("/api/v1/template", getTemplateHandler(templates)).Methods("GET")
+	staff.HandleFunc("/api/v1/template", putTemplateHandler(templates, requireAuth)).Methods("PUT")
+	return r
+}
+
+func TestHealthCheckBypassesAuthThroughRouter(t *testing.T) {
+	r := newTestStaffRouter(t, true)
+
+	req, err := http.NewRequest("GET", "/health", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v (health check must not require auth)",
+			status, http.StatusOK)
+	}
+}
+
+func TestGenerateBulkReportHandlerForbiddenForStudent(t *testing.T) {
+	r := newTestStaffRouter(t, true)
+
+	req, err := http.NewRequest("GET", "/api/v1/students/reports", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, authn.RoleStudent, "2"))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusForbidden {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusForbidden)
+	}
+}
+
+func TestReportStatusHandlerForbiddenForStudent(t *testing.T) {
+	r := newTestStaffRouter(t, true)
+
+	req, err := http.NewRequest("GET", "/api/v1/students/reports/status.json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, authn.RoleStudent, "2"))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusForbidden {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusForbidden)
+	}
+}
+
+func TestReportStatusHandlerAllowedForTeacher(t *testing.T) {
+	r := newTestStaffRouter(t, true)
+
+	req, err := http.NewRequest("GET", "/api/v1/students/reports/status.json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, authn.RoleTeacher, "99"))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+}
+
+func TestGetTemplateHandlerAllowedForTeacher(t *testing.T) {
+	r := newTestStaffRouter(t, true)
+
+	req, err := http.NewRequest("GET", "/api/v1/template", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, authn.RoleTeacher, "99"))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+}
+
+func TestGetTemplateHandlerForbiddenForStudent(t *testing.T) {
+	r := newTestStaffRouter(t, true)
+
+	req, err := http.NewRequest("GET", "/api/v1/template", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, authn.RoleStudent, "2"))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusForbidden {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusForbidden)
+	}
+}
+
+func TestPutTemplateHandlerAllowedForAdmin(t *testing.T) {
+	r := newTestStaffRouter(t, true)
+
+	body := strings.NewReader(`{"pageSize":"A4","headerText":"h","footerText":"f","sections":[]}`)
+	req, err := http.NewRequest("PUT", "/api/v1/template", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, authn.RoleAdmin, "99"))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+}
+
+func TestPutTemplateHandlerForbiddenForTeacher(t *testing.T) {
+	// RequireStaff lets teachers through, but putTemplateHandler itself
+	// restricts the write path to admins only.
+	r := newTestStaffRouter(t, true)
+
+	body := strings.NewReader(`{"pageSize":"A4","headerText":"h","footerText":"f","sections":[]}`)
+	req, err := http.NewRequest("PUT", "/api/v1/template", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, authn.RoleTeacher, "99"))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusForbidden {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusForbidden)
+	}
+}
+
+func TestPutTemplateHandlerForbiddenForStudent(t *testing.T) {
+	r := newTestStaffRouter(t, true)
+
+	body := strings.NewReader(`{"pageSize":"A4","headerText":"h","footerText":"f","sections":[]}`)
+	req, err := http.NewRequest("PUT", "/api/v1/template", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, authn.RoleStudent, "2"))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusForbidden {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusForbidden)
+	}
+}
+
+func TestPutTemplateHandlerMissingToken(t *testing.T) {
+	r := newTestStaffRouter(t, true)
+
+	body := strings.NewReader(`{"pageSize":"A4","headerText":"h","footerText":"f","sections":[]}`)
+	req, err := http.NewRequest("PUT", "/api/v1/template", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnauthorized {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusUnauthorized)
+	}
+}
+
+func TestReportManifestResetsPerBulkRun(t *testing.T) {
+	templates, err := template.NewStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportCache, err := cache.New(cache.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wideRoster := fakeRosterClient{students: []backend.StudentDetail{{ID: 1}, {ID: 2}}}
+	bulkHandler := generateBulkReportHandler(wideRoster, templates, reportCache, time.Minute)
+
+	req := httptest.NewRequest("GET", "/api/v1/students/reports", nil)
+	rr := httptest.NewRecorder()
+	bulkHandler(rr, req)
+
+	if _, ok := reportManifest.entries["1"]; !ok {
+		t.Fatal("manifest missing entry for student 1 after first run")
+	}
+	if _, ok := reportManifest.entries["2"]; !ok {
+		t.Fatal("manifest missing entry for student 2 after first run")
+	}
+
+	// A second, narrower run (e.g. filtered to a single class) should leave
+	// the manifest reflecting only its own students, not a merge with the
+	// first run's.
+	narrowRoster := fakeRosterClient{students: []backend.StudentDetail{{ID: 2}}}
+	bulkHandler = generateBulkReportHandler(narrowRoster, templates, reportCache, time.Minute)
+
+	req = httptest.NewRequest("GET", "/api/v1/students/reports?class=5", nil)
+	rr = httptest.NewRecorder()
+	bulkHandler(rr, req)
+
+	if _, ok := reportManifest.entries["1"]; ok {
+		t.Error("manifest still has stale entry for student 1 from the earlier, wider run")
+	}
+	if _, ok := reportManifest.entries["2"]; !ok {
+		t.Error("manifest missing entry for student 2 after second run")
+	}
+}
+
+func TestReportManifestNotCorruptedByOverlappingBulkRuns(t *testing.T) {
+	templates, err := template.NewStore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportCache, err := cache.New(cache.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := make(chan struct{})
+	slowRoster := blockingRosterClient{
+		fakeRosterClient: fakeRosterClient{students: []backend.StudentDetail{{ID: 1}, {ID: 2}}},
+		block:            map[string]chan struct{}{"1": block, "2": block},
+	}
+	slowHandler := generateBulkReportHandler(slowRoster, templates, reportCache, time.Minute)
+
+	fastRoster := fakeRosterClient{students: []backend.StudentDetail{{ID: 3}}}
+	fastHandler := generateBulkReportHandler(fastRoster, templates, reportCache, time.Minute)
+
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		req := httptest.NewRequest("GET", "/api/v1/students/reports", nil)
+		rr := httptest.NewRecorder()
+		slowHandler(rr, req)
+	}()
+
+	// Give the slow run's goroutines a moment to start (and block on
+	// `block`) before the fast run races past it to completion.
+	time.Sleep(50 * time.Millisecond)
+
+	req := httptest.NewRequest("GET", "/api/v1/students/reports?class=5", nil)
+	rr := httptest.NewRecorder()
+	fastHandler(rr, req)
+
+	if _, ok := reportManifest.entries["3"]; !ok {
+		t.Fatal("manifest missing entry for student 3 from the fast run, which completed first")
+	}
+
+	close(block)
+	<-slowDone
+
+	// The slow run's manifest should entirely replace the fast run's once it
+	// completes - not merge with it, and not be corrupted by the fast run
+	// having reset the shared map out from under it mid-flight.
+	if _, ok := reportManifest.entries["3"]; ok {
+		t.Error("manifest still has the fast run's entry after the slow run completed")
+	}
+	if _, ok := reportManifest.entries["1"]; !ok {
+		t.Error("manifest missing entry for student 1 from the slow run")
+	}
+	if _, ok := reportManifest.entries["2"]; !ok {
+		t.Error("manifest missing entry for student 2 from the slow run")
+	}
+}
+
+func TestGenerateReportHandlerForbiddenForOtherStudent(t *testing.T) {
+	r := newTestReportRouter(t, true)
+
+	req, err := http.NewRequest("GET", "/api/v1/students/1/report", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, authn.RoleStudent, "2"))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusForbidden {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusForbidden)
+	}
 }
\ No newline at end of file